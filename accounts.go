@@ -0,0 +1,32 @@
+package sunshine
+
+import (
+	"os"
+	"strings"
+)
+
+// ScanAccountDatabases analyzes /etc/passwd, /etc/group, /etc/shadow, /etc/gshadow,
+// and their - backup copies, per CIS benchmarks.
+//
+// Debian-like systems keep shadow databases at 0640 root:shadow,
+// while ProfileRedHat systems keep them at 0000 root:root.
+func (o Scanner) ScanAccountDatabases(pth string, info os.FileInfo) {
+	switch strings.TrimSuffix(pth, "-") {
+	case "/etc/passwd", "/etc/group":
+		o.ValidateFile(pth, info)
+		o.ValidateChmod(pth, info, 0644)
+		o.ValidateOwner(pth, info, "root")
+		o.ValidateGroup(pth, info, "root")
+	case "/etc/shadow", "/etc/gshadow":
+		o.ValidateFile(pth, info)
+		o.ValidateOwner(pth, info, "root")
+
+		if o.HasProfile(ProfileRedHat) {
+			o.ValidateChmod(pth, info, 0000)
+			o.ValidateGroup(pth, info, "root")
+		} else {
+			o.ValidateChmod(pth, info, 0640)
+			o.ValidateGroup(pth, info, "shadow")
+		}
+	}
+}
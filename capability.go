@@ -0,0 +1,262 @@
+package sunshine
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// CapabilityNames lists Linux capabilities, indexed by capability number.
+var CapabilityNames = []string{
+	"cap_chown",
+	"cap_dac_override",
+	"cap_dac_read_search",
+	"cap_fowner",
+	"cap_fsetid",
+	"cap_kill",
+	"cap_setgid",
+	"cap_setuid",
+	"cap_setpcap",
+	"cap_linux_immutable",
+	"cap_net_bind_service",
+	"cap_net_broadcast",
+	"cap_net_admin",
+	"cap_net_raw",
+	"cap_ipc_lock",
+	"cap_ipc_owner",
+	"cap_sys_module",
+	"cap_sys_rawio",
+	"cap_sys_chroot",
+	"cap_sys_ptrace",
+	"cap_sys_pacct",
+	"cap_sys_admin",
+	"cap_sys_boot",
+	"cap_sys_nice",
+	"cap_sys_resource",
+	"cap_sys_time",
+	"cap_sys_tty_config",
+	"cap_mknod",
+	"cap_lease",
+	"cap_audit_write",
+	"cap_audit_control",
+	"cap_setfcap",
+	"cap_mac_override",
+	"cap_mac_admin",
+	"cap_syslog",
+	"cap_wake_alarm",
+	"cap_block_suspend",
+	"cap_audit_read",
+	"cap_perfmon",
+	"cap_bpf",
+	"cap_checkpoint_restore",
+}
+
+// CapabilityAllowlists maps distribution profiles to glob patterns
+// matching their stock files, and the capabilities those files are expected to carry.
+var CapabilityAllowlists = map[string]map[string][]string{
+	ProfileDebian: {
+		"/usr/bin/arping":               {"cap_net_raw"},
+		"/usr/bin/dumpcap":              {"cap_net_admin", "cap_net_raw"},
+		"/usr/bin/fping":                {"cap_net_raw"},
+		"/usr/bin/gnome-keyring-daemon": {"cap_ipc_lock"},
+		"/usr/bin/mtr-packet":           {"cap_net_raw"},
+		"/usr/bin/newgidmap":            {"cap_setgid"},
+		"/usr/bin/newuidmap":            {"cap_setuid"},
+		"/usr/bin/ping":                 {"cap_net_raw"},
+		"/usr/lib/*-linux-gnu/gstreamer1.0/gstreamer-1.0/gst-ptp-helper": {"cap_net_admin", "cap_net_bind_service"},
+		"/usr/sbin/clockdiff": {"cap_net_raw"},
+	},
+	ProfileRedHat: {
+		"/usr/bin/arping":                           {"cap_net_raw"},
+		"/usr/bin/clockdiff":                        {"cap_net_raw"},
+		"/usr/bin/dumpcap":                          {"cap_net_admin", "cap_net_raw"},
+		"/usr/bin/newgidmap":                        {"cap_setgid"},
+		"/usr/bin/newuidmap":                        {"cap_setuid"},
+		"/usr/bin/ping":                             {"cap_net_admin", "cap_net_raw"},
+		"/usr/libexec/gstreamer-1.0/gst-ptp-helper": {"cap_net_admin", "cap_net_bind_service"},
+		"/usr/sbin/arping":                          {"cap_net_raw"},
+		"/usr/sbin/clockdiff":                       {"cap_net_raw"},
+		"/usr/sbin/mtr-packet":                      {"cap_net_raw"},
+		"/usr/sbin/suexec":                          {"cap_setgid", "cap_setuid"},
+	},
+}
+
+// CapabilityAllowlist reports the stock file capability patterns for the enabled distribution profile.
+func (o Scanner) CapabilityAllowlist() map[string][]string {
+	if o.HasProfile(ProfileRedHat) {
+		return CapabilityAllowlists[ProfileRedHat]
+	}
+
+	return CapabilityAllowlists[ProfileDebian]
+}
+
+// allowedCapabilities collects the capabilities allowlisted for a path.
+func (o Scanner) allowedCapabilities(pth string) []string {
+	var allowed []string
+
+	for pattern, names := range o.CapabilityAllowlist() {
+		if matchesAny(pth, []string{pattern}) {
+			allowed = append(allowed, names...)
+		}
+	}
+
+	return allowed
+}
+
+// Capabilities models a decoded security.capability extended attribute.
+type Capabilities struct {
+	// Effective denotes whether permitted capabilities are raised on execution.
+	Effective bool
+
+	// Permitted lists the permitted capability set.
+	Permitted []string
+
+	// Inheritable lists the inheritable capability set.
+	Inheritable []string
+}
+
+// Names lists the union of permitted and inheritable capabilities.
+func (o Capabilities) Names() []string {
+	names := slices.Clone(o.Permitted)
+
+	for _, name := range o.Inheritable {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// String renders capabilities in getcap notation, e.g. cap_net_raw=ep.
+func (o Capabilities) String() string {
+	flags := ""
+
+	if o.Effective {
+		flags += "e"
+	}
+
+	if len(o.Inheritable) != 0 {
+		flags += "i"
+	}
+
+	if len(o.Permitted) != 0 {
+		flags += "p"
+	}
+
+	return fmt.Sprintf("%s=%s", strings.Join(o.Names(), ","), flags)
+}
+
+// capabilitySet decodes a 64 bit capability mask into names.
+func capabilitySet(mask uint64) []string {
+	var names []string
+
+	for i := range 64 {
+		if mask&(1<<i) == 0 {
+			continue
+		}
+
+		if i < len(CapabilityNames) {
+			names = append(names, CapabilityNames[i])
+		} else {
+			names = append(names, fmt.Sprintf("cap_%d", i))
+		}
+	}
+
+	return names
+}
+
+// DecodeCapabilities parses a VFS security.capability extended attribute,
+// in revision 1, 2, or 3 format.
+func DecodeCapabilities(data []byte) (*Capabilities, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("truncated capability data")
+	}
+
+	magic := binary.LittleEndian.Uint32(data)
+	var words int
+
+	switch magic & 0xFF000000 {
+	case 0x01000000:
+		words = 1
+	case 0x02000000, 0x03000000:
+		words = 2
+	default:
+		return nil, fmt.Errorf("unknown capability revision %#x", magic&0xFF000000)
+	}
+
+	if len(data) < 4+8*words {
+		return nil, fmt.Errorf("truncated capability data")
+	}
+
+	var permitted, inheritable uint64
+
+	for i := range words {
+		offset := 4 + 8*i
+		permitted |= uint64(binary.LittleEndian.Uint32(data[offset:])) << (32 * i)
+		inheritable |= uint64(binary.LittleEndian.Uint32(data[offset+4:])) << (32 * i)
+	}
+
+	capabilities := Capabilities{
+		Effective:   magic&0x000001 != 0,
+		Permitted:   capabilitySet(permitted),
+		Inheritable: capabilitySet(inheritable),
+	}
+	return &capabilities, nil
+}
+
+// ScanCapabilities analyzes file capabilities,
+// flagging capabilities outside of the distribution allowlist,
+// and capable files writable by non-root users.
+//
+// Platforms without file capabilities skip this check.
+func (o Scanner) ScanCapabilities(pth string, info os.FileInfo) {
+	if !info.Mode().IsRegular() {
+		return
+	}
+
+	data, err := fileCapabilities(pth)
+
+	if err != nil || len(data) == 0 {
+		return
+	}
+
+	capabilities, err := DecodeCapabilities(data)
+
+	if err != nil {
+		o.ErrCh <- fmt.Errorf("%s: %v", pth, err)
+		return
+	}
+
+	names := capabilities.Names()
+
+	if len(names) == 0 {
+		return
+	}
+
+	if o.Debug {
+		o.DebugCh <- fmt.Sprintf("capabilities: %s (%s)", pth, capabilities)
+	}
+
+	absPth, err := filepath.Abs(pth)
+
+	if err != nil {
+		absPth = pth
+	}
+
+	allowed := o.allowedCapabilities(absPth)
+
+	for _, name := range names {
+		if !slices.Contains(allowed, name) {
+			o.Warn(pth, fmt.Sprintf("unexpected file capabilities, got %s", capabilities))
+			break
+		}
+	}
+
+	t := o.Tagged(fmt.Sprintf("file capabilities %s", capabilities))
+	t.ValidateChmodForbid(pth, info, 0022)
+	t.ValidateOwner(pth, info, "root")
+}
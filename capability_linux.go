@@ -0,0 +1,24 @@
+//go:build linux
+
+package sunshine
+
+import (
+	"errors"
+	"syscall"
+)
+
+// fileCapabilities reads the raw security.capability extended attribute of a path.
+func fileCapabilities(pth string) ([]byte, error) {
+	buf := make([]byte, 64)
+	size, err := syscall.Getxattr(pth, "security.capability", buf)
+
+	if errors.Is(err, syscall.ENODATA) || errors.Is(err, syscall.ENOTSUP) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buf[:size], nil
+}
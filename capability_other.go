@@ -0,0 +1,8 @@
+//go:build !linux
+
+package sunshine
+
+// fileCapabilities reports that file capabilities are unavailable on this platform.
+func fileCapabilities(_ string) ([]byte, error) {
+	return nil, nil
+}
@@ -0,0 +1,109 @@
+package sunshine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProfileCISKubernetes selects CIS Kubernetes Benchmark node file checks.
+const ProfileCISKubernetes = "cis-kubernetes"
+
+// CISKubernetesRule models a CIS Kubernetes Benchmark file permission and ownership control pair.
+type CISKubernetesRule struct {
+	// Chmod denotes the most permissive acceptable chmod.
+	Chmod os.FileMode
+
+	// ChmodControl identifies the permission control, if any.
+	ChmodControl string
+
+	// Owner denotes the expected owner and group.
+	Owner string
+
+	// OwnerControl identifies the ownership control.
+	OwnerControl string
+}
+
+// CISKubernetesFiles maps control plane and worker node paths to CIS Kubernetes Benchmark rules.
+var CISKubernetesFiles = map[string]CISKubernetesRule{
+	"/etc/kubernetes/manifests/kube-apiserver.yaml":             {0600, "1.1.1", "root", "1.1.2"},
+	"/etc/kubernetes/manifests/kube-controller-manager.yaml":    {0600, "1.1.3", "root", "1.1.4"},
+	"/etc/kubernetes/manifests/kube-scheduler.yaml":             {0600, "1.1.5", "root", "1.1.6"},
+	"/etc/kubernetes/manifests/etcd.yaml":                       {0600, "1.1.7", "root", "1.1.8"},
+	"/var/lib/etcd":                                             {0700, "1.1.11", "etcd", "1.1.12"},
+	"/etc/kubernetes/admin.conf":                                {0600, "1.1.13", "root", "1.1.14"},
+	"/etc/kubernetes/super-admin.conf":                          {0600, "1.1.13", "root", "1.1.14"},
+	"/etc/kubernetes/scheduler.conf":                            {0600, "1.1.15", "root", "1.1.16"},
+	"/etc/kubernetes/controller-manager.conf":                   {0600, "1.1.17", "root", "1.1.18"},
+	"/etc/kubernetes/pki":                                       {0, "", "root", "1.1.19"},
+	"/etc/systemd/system/kubelet.service.d/10-kubeadm.conf":     {0600, "4.1.1", "root", "4.1.2"},
+	"/usr/lib/systemd/system/kubelet.service.d/10-kubeadm.conf": {0600, "4.1.1", "root", "4.1.2"},
+	"/var/lib/kube-proxy/kubeconfig.conf":                       {0600, "4.1.3", "root", "4.1.4"},
+	"/etc/kubernetes/kubelet.conf":                              {0600, "4.1.5", "root", "4.1.6"},
+	"/var/lib/kubelet/config.yaml":                              {0600, "4.1.9", "root", "4.1.10"},
+}
+
+// CISKubernetesManifestRule covers other static pod manifests.
+var CISKubernetesManifestRule = CISKubernetesRule{0600, "1.1.1", "root", "1.1.2"}
+
+// CISKubernetesCNIRule covers container network interface files.
+var CISKubernetesCNIRule = CISKubernetesRule{0600, "1.1.9", "root", "1.1.10"}
+
+// CISKubernetesPKICertRule covers Kubernetes PKI certificate files.
+var CISKubernetesPKICertRule = CISKubernetesRule{0600, "1.1.20", "root", "1.1.19"}
+
+// CISKubernetesPKIKeyRule covers Kubernetes PKI key files.
+var CISKubernetesPKIKeyRule = CISKubernetesRule{0600, "1.1.21", "root", "1.1.19"}
+
+// CISKubernetesPKIRule covers other Kubernetes PKI files.
+var CISKubernetesPKIRule = CISKubernetesRule{0, "", "root", "1.1.19"}
+
+// CISKubernetesRuleFor resolves the CIS Kubernetes Benchmark rule covering a path.
+func CISKubernetesRuleFor(pth string, info os.FileInfo) (CISKubernetesRule, bool) {
+	if rule, ok := CISKubernetesFiles[pth]; ok {
+		return rule, true
+	}
+
+	if info.IsDir() {
+		return CISKubernetesRule{}, false
+	}
+
+	switch {
+	case filepath.Dir(pth) == "/etc/kubernetes/manifests" && filepath.Ext(pth) == ".yaml":
+		return CISKubernetesManifestRule, true
+	case strings.HasPrefix(pth, "/etc/cni/net.d/"):
+		return CISKubernetesCNIRule, true
+	case strings.HasPrefix(pth, "/etc/kubernetes/pki/") && filepath.Ext(pth) == ".crt":
+		return CISKubernetesPKICertRule, true
+	case strings.HasPrefix(pth, "/etc/kubernetes/pki/") && filepath.Ext(pth) == ".key":
+		return CISKubernetesPKIKeyRule, true
+	case strings.HasPrefix(pth, "/etc/kubernetes/pki/"):
+		return CISKubernetesPKIRule, true
+	}
+
+	return CISKubernetesRule{}, false
+}
+
+// ScanCISKubernetes analyzes Kubernetes control plane and worker node files
+// per CIS Kubernetes Benchmark sections 1.1 and 4.1,
+// tagging each finding with its control ID.
+func (o Scanner) ScanCISKubernetes(pth string, info os.FileInfo) {
+	if !o.HasProfile(ProfileCISKubernetes) {
+		return
+	}
+
+	rule, ok := CISKubernetesRuleFor(pth, info)
+
+	if !ok {
+		return
+	}
+
+	if rule.ChmodControl != "" {
+		o.Tagged(fmt.Sprintf("CIS %s", rule.ChmodControl)).ValidateChmodMax(pth, info, rule.Chmod)
+	}
+
+	t := o.Tagged(fmt.Sprintf("CIS %s", rule.OwnerControl))
+	t.ValidateOwner(pth, info, rule.Owner)
+	t.ValidateGroup(pth, info, rule.Owner)
+}
@@ -0,0 +1,52 @@
+package sunshine
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// CloudCredentialFiles maps cloud provider configuration directories,
+// relative to a home directory, to the credential files they hold.
+var CloudCredentialFiles = map[string][]string{
+	".aws": {
+		"credentials",
+		"config",
+	},
+	".config/gcloud": {
+		"application_default_credentials.json",
+		"credentials.db",
+		"access_tokens.db",
+	},
+	".azure": {
+		"accessTokens.json",
+		"msal_token_cache.json",
+		"msal_token_cache.bin",
+		"service_principal_entries.json",
+	},
+}
+
+// ScanCloudCredentials analyzes AWS, Google Cloud, and Azure credential directories and files.
+func (o Scanner) ScanCloudCredentials(pth string, info os.FileInfo) {
+	for dir, names := range CloudCredentialFiles {
+		if hasPathSuffix(pth, dir) {
+			home := filepath.Dir(pth)
+
+			for range strings.Count(dir, "/") {
+				home = filepath.Dir(home)
+			}
+
+			o.ValidateDirectory(pth, info)
+			o.ValidateChmodForbid(pth, info, 0077)
+			o.ValidateOwnerOf(pth, info, home)
+			return
+		}
+
+		if slices.Contains(names, info.Name()) && hasPathSuffix(filepath.Dir(pth), dir) {
+			o.ValidateFile(pth, info)
+			o.ValidateChmodForbid(pth, info, 0077)
+			return
+		}
+	}
+}
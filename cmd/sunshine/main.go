@@ -8,9 +8,20 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strings"
 )
 
 var flagDebug = flag.Bool("debug", false, "Enable additional logging")
+var flagKubeConfig = flag.String("kubeconfig", "", "Additional Kubernetes client configuration files, as a KUBECONFIG style path list")
+var flagDocumentRoots = flag.String("docroot", "", fmt.Sprintf("Web server document roots, as a path list (default %s)", strings.Join(sunshine.DefaultDocumentRoots, string(filepath.ListSeparator))))
+var flagStrictSecrets = flag.Bool("strict-secrets", false, "Require application secret files to not be group readable")
+var flagProfile = flag.String("profile", sunshine.ProfileDebian, fmt.Sprintf("Comma separated profiles (%s)", strings.Join(sunshine.Profiles, ", ")))
+var flagPathAudit = flag.Bool("path-audit", false, "Audit executable search path directories instead of scanning files")
+var flagPath = flag.String("path", "", "Executable search path to audit (default $PATH)")
+var flagUser = flag.String("user", "", "Target user for the executable search path audit (default current user)")
 var flagVersion = flag.Bool("version", false, "Show version information")
 var flagHelp = flag.Bool("help", false, "Show usage information")
 
@@ -26,6 +37,11 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *flagPathAudit && flag.NArg() != 0 {
+		fmt.Println("-path-audit does not accept file paths; use -path")
+		os.Exit(1)
+	}
+
 	debug := *flagDebug
 	roots := flag.Args()
 
@@ -40,13 +56,59 @@ func main() {
 		roots = []string{cwd}
 	}
 
-	scanner, err := sunshine.Illuminate(roots, debug)
+	scanner, err := sunshine.NewScanner(debug)
 
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
+	scanner.InfoCh = make(chan string)
+
+	if *flagKubeConfig != "" {
+		scanner.KubeConfigs = filepath.SplitList(*flagKubeConfig)
+	}
+
+	if *flagDocumentRoots != "" {
+		scanner.DocumentRoots = filepath.SplitList(*flagDocumentRoots)
+	}
+
+	scanner.StrictSecrets = *flagStrictSecrets
+
+	for _, profile := range strings.Split(*flagProfile, ",") {
+		if !slices.Contains(sunshine.Profiles, profile) {
+			fmt.Printf("unknown profile: %s\n", profile)
+			os.Exit(1)
+		}
+
+		scanner.Profiles = append(scanner.Profiles, profile)
+	}
+
+	if *flagPathAudit {
+		searchPath := *flagPath
+
+		if searchPath == "" {
+			searchPath = os.Getenv("PATH")
+		}
+
+		username := *flagUser
+
+		if username == "" {
+			u, err2 := user.Current()
+
+			if err2 != nil {
+				fmt.Println(err2)
+				os.Exit(1)
+			}
+
+			username = u.Username
+		}
+
+		scanner.AuditPath(searchPath, username)
+	} else {
+		scanner.Illuminate(roots)
+	}
+
 	var msg string
 	clean := true
 
@@ -57,6 +119,8 @@ func main() {
 		case msg = <-scanner.WarnCh:
 			clean = false
 			log.Printf("warning: %s", msg)
+		case msg = <-scanner.InfoCh:
+			log.Printf("info: %s", msg)
 		case err = <-scanner.ErrCh:
 			clean = false
 			log.Println(err)
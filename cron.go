@@ -0,0 +1,72 @@
+package sunshine
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// CronDirectories lists system cron job directories.
+var CronDirectories = []string{
+	"/etc/cron.d",
+	"/etc/cron.hourly",
+	"/etc/cron.daily",
+	"/etc/cron.weekly",
+	"/etc/cron.monthly",
+}
+
+// CronAccessFiles lists cron and at access control files.
+var CronAccessFiles = []string{
+	"/etc/cron.allow",
+	"/etc/cron.deny",
+	"/etc/at.allow",
+	"/etc/at.deny",
+}
+
+// CronSpoolDirectories lists per-user crontab directories, for Debian-like and Red Hat-like systems.
+var CronSpoolDirectories = []string{
+	"/var/spool/cron/crontabs",
+	"/var/spool/cron",
+}
+
+// ScanCron analyzes system crontabs, cron job directories, and access control files.
+//
+// run-parts skips scripts with a dot in their names, so only the remaining scripts must be executable.
+func (o Scanner) ScanCron(pth string, info os.FileInfo) {
+	parent := filepath.Dir(pth)
+
+	switch {
+	case pth == "/etc/crontab":
+		o.ValidateFile(pth, info)
+		o.ValidateChmodForbid(pth, info, 0022)
+		o.ValidateOwner(pth, info, "root")
+	case slices.Contains(CronDirectories, pth):
+		o.ValidateDirectory(pth, info)
+		o.ValidateChmodForbid(pth, info, 0022)
+		o.ValidateOwner(pth, info, "root")
+	case slices.Contains(CronDirectories, parent):
+		o.ValidateFile(pth, info)
+		o.ValidateChmodForbid(pth, info, 0022)
+		o.ValidateOwner(pth, info, "root")
+
+		if parent != "/etc/cron.d" && !strings.Contains(info.Name(), ".") {
+			o.ValidateChmodMask(pth, info, 0100)
+		}
+	case slices.Contains(CronAccessFiles, pth):
+		o.ValidateFile(pth, info)
+		o.ValidateChmodForbid(pth, info, 0137)
+		o.ValidateOwner(pth, info, "root")
+	}
+}
+
+// ScanCrontabs analyzes per-user crontabs, which must be owned by the user they are named after.
+func (o Scanner) ScanCrontabs(pth string, info os.FileInfo) {
+	if info.IsDir() || !slices.Contains(CronSpoolDirectories, filepath.Dir(pth)) {
+		return
+	}
+
+	o.ValidateFile(pth, info)
+	o.ValidateChmod(pth, info, 0600)
+	o.ValidateOwner(pth, info, info.Name())
+}
@@ -0,0 +1,134 @@
+package sunshine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// RedisDataNames lists Redis persistence files and directories.
+var RedisDataNames = []string{
+	"dump.rdb",
+	"appendonly.aof",
+	"appendonlydir",
+}
+
+// RedisConfigNames lists Redis configuration files, which may hold requirepass and masterauth secrets.
+var RedisConfigNames = []string{
+	"redis.conf",
+	"sentinel.conf",
+}
+
+// MySQLSchemaMarkers lists files found in the mysql system schema directory of MySQL and MariaDB datadirs.
+var MySQLSchemaMarkers = []string{
+	"db.opt",
+	"user.frm",
+	"global_priv.frm",
+	"general_log.CSV",
+}
+
+// exists reports whether a path exists, with the given directory-ness.
+func exists(pth string, dir bool) bool {
+	info, err := os.Stat(pth)
+	return err == nil && info.IsDir() == dir
+}
+
+// IsPostgresData reports whether a directory is a PostgreSQL data directory,
+// recognized by its PG_VERSION marker alongside a global directory.
+//
+// Per-database base/<oid>/PG_VERSION markers lack the global directory.
+func IsPostgresData(dir string) bool {
+	return exists(filepath.Join(dir, "PG_VERSION"), false) && exists(filepath.Join(dir, "global"), true)
+}
+
+// IsMySQLData reports whether a directory is a MySQL or MariaDB data directory,
+// recognized by its ibdata1 system tablespace or by its mysql system schema directory.
+func IsMySQLData(dir string) bool {
+	if exists(filepath.Join(dir, "ibdata1"), false) {
+		return true
+	}
+
+	for _, marker := range MySQLSchemaMarkers {
+		if exists(filepath.Join(dir, "mysql", marker), false) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ScanDataDirectories analyzes PostgreSQL and MySQL data directories.
+//
+// PostgreSQL refuses to start unless PGDATA is chmod 0700 or 0750.
+func (o Scanner) ScanDataDirectories(pth string, info os.FileInfo) {
+	if !info.IsDir() {
+		return
+	}
+
+	switch {
+	case IsPostgresData(pth):
+		o.Tagged("PGDATA").ValidateDataDirectory(pth, info)
+	case IsMySQLData(pth):
+		o.Tagged("MySQL datadir").ValidateDataDirectory(pth, info)
+	}
+}
+
+// ScanDataOwnership analyzes paths inside of data directories found by ScanDataDirectories,
+// which must be owned by the service account owning the data directory.
+//
+// Walk visits data directories before their contents.
+func (o Scanner) ScanDataOwnership(pth string, info os.FileInfo) {
+	if o.dataDirectories == nil {
+		return
+	}
+
+	uid, _, ok := fileOwnership(info)
+
+	if !ok {
+		return
+	}
+
+	o.dataDirectories.Range(func(key, value any) bool {
+		dataDir := key.(string)
+		dataUID := value.(uint32)
+
+		if samePath(pth, dataDir) || !within(pth, dataDir) {
+			return true
+		}
+
+		if uid != dataUID {
+			o.Warn(pth, fmt.Sprintf("expected owner %s (same as %s), got %s", userName(dataUID), dataDir, userName(uid)))
+		}
+
+		return false
+	})
+}
+
+// ScanRedis analyzes Redis persistence files and configuration files.
+func (o Scanner) ScanRedis(pth string, info os.FileInfo) {
+	name := info.Name()
+
+	switch {
+	case slices.Contains(RedisDataNames, name):
+		t := o.Tagged("Redis data")
+		t.ValidateChmodForbid(pth, info, 0077)
+		t.ValidateOwnerOf(pth, info, filepath.Dir(pth))
+	case slices.Contains(RedisConfigNames, name):
+		o.ValidateFile(pth, info)
+		o.Tagged("Redis config").ValidateChmodForbid(pth, info, 0027)
+	}
+}
+
+// ValidateDataDirectory enforces owner-only database data directory modes,
+// and registers the directory owner for ScanDataOwnership.
+func (o *Scanner) ValidateDataDirectory(dataDir string, info os.FileInfo) {
+	o.ValidateDirectory(dataDir, info)
+	o.ValidateChmodAny(dataDir, info, 0700, 0750)
+
+	dataUID, _, ok := fileOwnership(info)
+
+	if ok && o.dataDirectories != nil {
+		o.dataDirectories.Store(dataDir, dataUID)
+	}
+}
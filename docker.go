@@ -0,0 +1,60 @@
+package sunshine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ScanDockerClientConfig analyzes .docker/config.json files, which may embed registry auths.
+func (o Scanner) ScanDockerClientConfig(pth string, info os.FileInfo) {
+	if hasPathSuffix(pth, ".docker/config.json") {
+		o.ValidateFile(pth, info)
+		o.ValidateChmod(pth, info, 0600)
+	}
+}
+
+// ScanDockerDaemon analyzes /etc/docker and /etc/docker/daemon.json,
+// accepting CIS Docker Benchmark modes or more restrictive.
+func (o Scanner) ScanDockerDaemon(pth string, info os.FileInfo) {
+	switch pth {
+	case "/etc/docker":
+		o.ValidateDirectory(pth, info)
+		o.ValidateChmodMax(pth, info, 0755)
+		o.ValidateOwner(pth, info, "root")
+	case "/etc/docker/daemon.json":
+		o.ValidateFile(pth, info)
+		o.ValidateChmodMax(pth, info, 0644)
+		o.ValidateOwner(pth, info, "root")
+		o.ValidateGroup(pth, info, "root")
+	}
+}
+
+// ScanDockerCerts analyzes TLS material under /etc/docker/certs.d,
+// accepting CIS Docker Benchmark modes or more restrictive.
+func (o Scanner) ScanDockerCerts(pth string, info os.FileInfo) {
+	if !strings.HasPrefix(pth, "/etc/docker/certs.d/") || info.IsDir() {
+		return
+	}
+
+	o.ValidateFile(pth, info)
+
+	if filepath.Ext(pth) == ".key" {
+		o.ValidateChmodMax(pth, info, 0400)
+	} else {
+		o.ValidateChmodMax(pth, info, 0444)
+	}
+
+	o.ValidateOwner(pth, info, "root")
+	o.ValidateGroup(pth, info, "root")
+}
+
+// ScanDockerSocket analyzes the Docker daemon socket.
+func (o Scanner) ScanDockerSocket(pth string, info os.FileInfo) {
+	if pth == "/var/run/docker.sock" || pth == "/run/docker.sock" {
+		o.ValidateSocket(pth, info)
+		o.ValidateChmod(pth, info, 0660)
+		o.ValidateOwner(pth, info, "root")
+		o.ValidateGroup(pth, info, "docker")
+	}
+}
@@ -0,0 +1,46 @@
+package sunshine
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// GitCredentialFiles lists plaintext git credential stores, relative to a home directory.
+var GitCredentialFiles = []string{
+	".git-credentials",
+	".config/git/credentials",
+}
+
+// ScanGitCredentials analyzes git credential store files.
+func (o Scanner) ScanGitCredentials(pth string, info os.FileInfo) {
+	for _, credentialFile := range GitCredentialFiles {
+		if hasPathSuffix(pth, credentialFile) {
+			o.ValidateFile(pth, info)
+			o.ValidateChmod(pth, info, 0600)
+			return
+		}
+	}
+}
+
+// ScanGitHooks analyzes .git/hooks/* files, which execute on common git operations.
+//
+// Sample hooks are ignored, as git never runs them.
+func (o Scanner) ScanGitHooks(pth string, info os.FileInfo) {
+	if info.IsDir() || strings.HasSuffix(info.Name(), ".sample") || !hasPathSuffix(filepath.Dir(pth), ".git/hooks") {
+		return
+	}
+
+	o.ValidateFile(pth, info)
+	o.Tagged("git skips hooks not executable by the owner").ValidateChmodMask(pth, info, 0100)
+	o.ValidateChmodForbid(pth, info, 0022)
+}
+
+// ScanGitConfig analyzes .git/config files, which may configure command hooks such as core.fsmonitor.
+func (o Scanner) ScanGitConfig(pth string, info os.FileInfo) {
+	if info.Name() == "config" && path.Base(filepath.Dir(pth)) == ".git" {
+		o.ValidateFile(pth, info)
+		o.Tagged("writers may inject commands via core.fsmonitor").ValidateChmodForbid(pth, info, 0022)
+	}
+}
@@ -0,0 +1,58 @@
+package sunshine
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+)
+
+// GnuPGFileNames lists sensitive files kept directly in a GnuPG home directory.
+var GnuPGFileNames = []string{
+	"gpg.conf",
+	"gpg-agent.conf",
+	"trustdb.gpg",
+	"pubring.kbx",
+	"pubring.gpg",
+	"secring.gpg",
+}
+
+// ScanGnuPGHome analyzes .gnupg directories.
+func (o Scanner) ScanGnuPGHome(pth string, info os.FileInfo) {
+	if info.Name() == ".gnupg" {
+		o.ValidateDirectory(pth, info)
+		o.ValidateChmod(pth, info, 0700)
+		o.ValidateOwnerOf(pth, info, filepath.Dir(pth))
+	}
+}
+
+// ScanGnuPGFiles analyzes .gnupg/(gpg.conf|gpg-agent.conf|trustdb.gpg|pubring.kbx|...) files.
+func (o Scanner) ScanGnuPGFiles(pth string, info os.FileInfo) {
+	if slices.Contains(GnuPGFileNames, info.Name()) {
+		parent := path.Base(filepath.Dir(pth))
+
+		if parent == ".gnupg" {
+			o.ValidateFile(pth, info)
+			o.ValidateChmod(pth, info, 0600)
+			o.ValidateOwnerOf(pth, info, filepath.Dir(filepath.Dir(pth)))
+		}
+	}
+}
+
+// ScanGnuPGPrivateKeys analyzes .gnupg/private-keys-v1.d directories and their key files.
+func (o Scanner) ScanGnuPGPrivateKeys(pth string, info os.FileInfo) {
+	parent := path.Base(filepath.Dir(pth))
+
+	if info.Name() == "private-keys-v1.d" && parent == ".gnupg" {
+		o.ValidateDirectory(pth, info)
+		o.ValidateChmod(pth, info, 0700)
+		o.ValidateOwnerOf(pth, info, filepath.Dir(filepath.Dir(pth)))
+		return
+	}
+
+	if parent == "private-keys-v1.d" && path.Base(filepath.Dir(filepath.Dir(pth))) == ".gnupg" {
+		o.ValidateFile(pth, info)
+		o.ValidateChmod(pth, info, 0600)
+		o.ValidateOwnerOf(pth, info, filepath.Dir(filepath.Dir(filepath.Dir(pth))))
+	}
+}
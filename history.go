@@ -0,0 +1,78 @@
+package sunshine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// HistoryFileNames lists shell and REPL history files, which often capture passwords typed on command lines.
+var HistoryFileNames = []string{
+	".bash_history",
+	".zsh_history",
+	".sh_history",
+	".python_history",
+	".psql_history",
+	".mysql_history",
+	".sqlite_history",
+	".node_repl_history",
+	".lesshst",
+}
+
+// ScanHistory analyzes shell and REPL history files.
+//
+// Symlinked history files are analyzed by ScanHistorySymlinks.
+func (o Scanner) ScanHistory(pth string, info os.FileInfo) {
+	if !slices.Contains(HistoryFileNames, info.Name()) || info.Mode()&os.ModeSymlink != 0 {
+		return
+	}
+
+	o.ValidateRegularFile(pth, info)
+	o.ValidateChmod(pth, info, 0600)
+	o.ValidateOwnerOf(pth, info, filepath.Dir(pth))
+}
+
+// ScanHistorySymlinks reports history files symlinked to /dev/null or to other users' files.
+//
+// pth denotes the symlink itself, rather than its target.
+func (o Scanner) ScanHistorySymlinks(pth string, info os.FileInfo) {
+	if !slices.Contains(HistoryFileNames, info.Name()) || info.Mode()&os.ModeSymlink == 0 {
+		return
+	}
+
+	target, err := filepath.EvalSymlinks(pth)
+
+	if err != nil {
+		return
+	}
+
+	if target == os.DevNull {
+		o.Inform(pth, fmt.Sprintf("history symlinked to %s", target))
+		return
+	}
+
+	targetInfo, err := os.Stat(target)
+
+	if err != nil {
+		return
+	}
+
+	homeInfo, err := os.Stat(filepath.Dir(pth))
+
+	if err != nil {
+		return
+	}
+
+	uid, _, ok := fileOwnership(targetInfo)
+
+	if !ok {
+		return
+	}
+
+	homeUID, _, ok := fileOwnership(homeInfo)
+
+	if ok && uid != homeUID {
+		o.Inform(pth, fmt.Sprintf("history symlinked to %s, owned by %s", target, userName(uid)))
+	}
+}
@@ -0,0 +1,45 @@
+package sunshine
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// PrivateKeyPattern matches PEM, PKCS#8, OpenSSH, and PuTTY private key headers,
+// including encrypted variants.
+var PrivateKeyPattern = regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY-----|PuTTY-User-Key-File-\d+:`)
+
+// PrivateKeyMaxSize denotes the largest file size considered for private key detection.
+const PrivateKeyMaxSize = 64 * 1024
+
+// ScanPrivateKeys analyzes the leading bytes of small Sniffable files for private key content,
+// regardless of filename.
+//
+// Certificates and public keys are ignored.
+// Keys already covered by ScanSSHKeys, ScanSSHHostKeys, and ScanDockerCerts are skipped.
+func (o Scanner) ScanPrivateKeys(pth string, info os.FileInfo, header []byte) {
+	if len(header) == 0 || info.Size() > PrivateKeyMaxSize {
+		return
+	}
+
+	if SSHKeyPattern.MatchString(info.Name()) && path.Base(filepath.Dir(pth)) == ".ssh" {
+		return
+	}
+
+	if SSHHostKeyPattern.MatchString(info.Name()) && filepath.Dir(pth) == "/etc/ssh" {
+		return
+	}
+
+	if strings.HasPrefix(pth, "/etc/docker/certs.d/") {
+		return
+	}
+
+	if !PrivateKeyPattern.Match(header) {
+		return
+	}
+
+	o.Tagged("private key").ValidateChmodAny(pth, info, 0600, 0400)
+}
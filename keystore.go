@@ -0,0 +1,53 @@
+package sunshine
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// KeystoreExtensions lists file extensions of Java keystores, PKCS#12 bundles, and KeePass databases.
+var KeystoreExtensions = []string{
+	".jks",
+	".jceks",
+	".keystore",
+	".p12",
+	".pfx",
+	".kdbx",
+}
+
+// KeystoreMagics lists leading bytes of JKS, JCEKS, and KeePass files.
+var KeystoreMagics = [][]byte{
+	{0xFE, 0xED, 0xFE, 0xED},
+	{0xCE, 0xCE, 0xCE, 0xCE},
+	{0x03, 0xD9, 0xA2, 0x9A, 0x67, 0xFB, 0x4B, 0xB5},
+	{0x03, 0xD9, 0xA2, 0x9A, 0x65, 0xFB, 0x4B, 0xB5},
+}
+
+// IsKeystore reports whether a file is a keystore,
+// by extension or by the magic bytes of its sniffed header.
+func IsKeystore(pth string, header []byte) bool {
+	if slices.Contains(KeystoreExtensions, strings.ToLower(filepath.Ext(pth))) {
+		return true
+	}
+
+	for _, magic := range KeystoreMagics {
+		if bytes.HasPrefix(header, magic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ScanKeystores analyzes Java keystores, PKCS#12 bundles, and KeePass databases.
+func (o Scanner) ScanKeystores(pth string, info os.FileInfo, header []byte) {
+	if info.IsDir() || !IsKeystore(pth, header) {
+		return
+	}
+
+	o.ValidateFile(pth, info)
+	o.Tagged("keystore").ValidateChmodForbid(pth, info, 0026)
+}
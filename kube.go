@@ -0,0 +1,76 @@
+package sunshine
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// KubeClientKeyData marks kubeconfig files embedding client private keys.
+var KubeClientKeyData = []byte("client-key-data:")
+
+// ScanKubeHome analyzes .kube directories.
+func (o Scanner) ScanKubeHome(pth string, info os.FileInfo) {
+	if info.Name() == ".kube" {
+		o.ValidateDirectory(pth, info)
+		o.ValidateChmod(pth, info, 0700)
+		o.ValidateOwnerOf(pth, info, filepath.Dir(pth))
+	}
+}
+
+// IsKubeConfig reports whether a path is a Kubernetes client configuration file,
+// either ~/.kube/config or one of the scanner's KubeConfigs.
+func (o Scanner) IsKubeConfig(pth string) bool {
+	if hasPathSuffix(pth, ".kube/config") {
+		return true
+	}
+
+	for _, kubeConfig := range o.KubeConfigs {
+		if samePath(pth, kubeConfig) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ScanKubeConfig analyzes Kubernetes client configuration files.
+func (o Scanner) ScanKubeConfig(pth string, info os.FileInfo) {
+	if !o.IsKubeConfig(pth) {
+		return
+	}
+
+	o.ValidateFile(pth, info)
+	o.ValidateChmod(pth, info, 0600)
+
+	if !info.Mode().IsRegular() || info.Mode()&0044 == 0 {
+		return
+	}
+
+	contents, err := os.ReadFile(pth)
+
+	if err != nil {
+		return
+	}
+
+	if bytes.Contains(contents, KubeClientKeyData) {
+		o.Warn(pth, fmt.Sprintf("embeds client-key-data readable by others, got %04o", Chmod(info)))
+	}
+}
+
+// ScanKubeCache analyzes .kube/cache directory trees.
+func (o Scanner) ScanKubeCache(pth string, info os.FileInfo) {
+	p := filepath.ToSlash(pth)
+
+	if !hasPathSuffix(p, ".kube/cache") && !strings.HasPrefix(p, ".kube/cache/") && !strings.Contains(p, "/.kube/cache/") {
+		return
+	}
+
+	if info.IsDir() {
+		o.ValidateChmod(pth, info, 0700)
+	} else {
+		o.ValidateChmod(pth, info, 0600)
+	}
+}
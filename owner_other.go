@@ -0,0 +1,12 @@
+//go:build !unix
+
+package sunshine
+
+import (
+	"os"
+)
+
+// fileOwnership reports that UNIX ownership is unavailable on this platform.
+func fileOwnership(_ os.FileInfo) (uint32, uint32, bool) {
+	return 0, 0, false
+}
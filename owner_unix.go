@@ -0,0 +1,19 @@
+//go:build unix
+
+package sunshine
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileOwnership reports the numeric owner and group of a path.
+func fileOwnership(info os.FileInfo) (uint32, uint32, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+
+	if !ok {
+		return 0, 0, false
+	}
+
+	return uint32(stat.Uid), uint32(stat.Gid), true
+}
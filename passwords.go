@@ -0,0 +1,42 @@
+package sunshine
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// PasswordFiles maps per-user client password file names
+// to the consequences of loose permissions.
+var PasswordFiles = map[string]string{
+	// libpq ignores ~/.pgpass, without error, when group or world accessible.
+	".pgpass": "libpq silently ignores this file unless chmod 0600 or stricter",
+
+	// curl, ftp, and git read plaintext credentials from ~/.netrc;
+	// ftp refuses to use the file when others can read it.
+	".netrc": "ftp rejects this file when readable by others, exposing plaintext passwords",
+
+	// Windows builds of curl look for _netrc instead.
+	"_netrc": "curl reads plaintext passwords from this file",
+
+	// MySQL clients ignore world-writable option files.
+	".my.cnf": "mysql clients ignore this file when world-writable, exposing plaintext passwords",
+
+	// mysql_config_editor obfuscates, but does not encrypt, login paths.
+	".mylogin.cnf": "mysql login paths are obfuscated, not encrypted",
+
+	// mount.cifs reads plaintext SMB credentials.
+	".smbcredentials": "mount.cifs reads plaintext passwords from this file",
+}
+
+// ScanPasswordFiles analyzes .netrc, .pgpass, .my.cnf, and similar client password files.
+func (o Scanner) ScanPasswordFiles(pth string, info os.FileInfo) {
+	note, ok := PasswordFiles[info.Name()]
+
+	if !ok {
+		return
+	}
+
+	o.ValidateRegularFile(pth, info)
+	o.Tagged(note).ValidateChmodMax(pth, info, 0600)
+	o.ValidateOwnerOf(pth, info, filepath.Dir(pth))
+}
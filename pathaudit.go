@@ -0,0 +1,122 @@
+package sunshine
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+)
+
+// PathAuditTag labels warnings about executable search path entries.
+const PathAuditTag = "PATH"
+
+// AuditPath checks every directory of an executable search path, along with its ancestors
+// and the ancestors of its symlink targets,
+// for writability by anyone other than root or the given user,
+// as well as empty and relative entries, which resolve against the current directory.
+func (o *Scanner) AuditPath(searchPath string, username string) {
+	go func() {
+		o.auditPath(searchPath, username)
+		o.DoneCh <- struct{}{}
+	}()
+}
+
+// auditPath implements AuditPath synchronously.
+func (o *Scanner) auditPath(searchPath string, username string) {
+	u, err := user.Lookup(username)
+
+	if err != nil {
+		o.ErrCh <- err
+		return
+	}
+
+	t := o.Tagged(PathAuditTag)
+	seen := make(map[string]bool)
+
+	for _, entry := range filepath.SplitList(searchPath) {
+		if entry == "" {
+			t.Warn(`""`, "empty entry resolves to the current directory")
+			continue
+		}
+
+		if !filepath.IsAbs(entry) {
+			t.Warn(entry, "relative entry resolves against the current directory")
+			continue
+		}
+
+		t.auditPathEntry(entry, u, seen)
+	}
+}
+
+// PathAuditMaxSymlinks limits how many symlink hops are followed per search path entry.
+const PathAuditMaxSymlinks = 255
+
+// auditPathEntry validates the ancestors of a search path entry,
+// of each hop in its symlink chain, and of its fully resolved location.
+func (o *Scanner) auditPathEntry(entry string, u *user.User, seen map[string]bool) {
+	pth := filepath.Clean(entry)
+
+	for range PathAuditMaxSymlinks {
+		o.auditPathAncestors(pth, u, seen)
+		info, err := os.Lstat(pth)
+
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			break
+		}
+
+		target, err := os.Readlink(pth)
+
+		if err != nil {
+			break
+		}
+
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(pth), target)
+		}
+
+		pth = filepath.Clean(target)
+	}
+
+	if resolved, err := filepath.EvalSymlinks(entry); err == nil {
+		o.auditPathAncestors(resolved, u, seen)
+	}
+}
+
+// auditPathAncestors validates a directory and its lexical ancestors, skipping those already seen.
+func (o *Scanner) auditPathAncestors(dir string, u *user.User, seen map[string]bool) {
+	for ; !seen[dir]; dir = filepath.Dir(dir) {
+		seen[dir] = true
+		o.validatePathDirectory(dir, u)
+	}
+}
+
+// validatePathDirectory enforces that only root or the given user may write to a directory.
+//
+// Missing directories are ignored, as their ancestors are checked as well.
+func (o *Scanner) validatePathDirectory(dir string, u *user.User) {
+	if o.Debug {
+		o.DebugCh <- fmt.Sprintf("scanning: %s", dir)
+	}
+
+	info, err := os.Stat(dir)
+
+	if err != nil {
+		return
+	}
+
+	o.ValidateDirectory(dir, info)
+	o.ValidateChmodForbid(dir, info, 0022)
+
+	uid, _, ok := fileOwnership(info)
+
+	if !ok {
+		return
+	}
+
+	observedUID := strconv.FormatUint(uint64(uid), 10)
+
+	if observedUID != "0" && observedUID != u.Uid {
+		o.Warn(dir, fmt.Sprintf("expected owner root or %s, got %s", u.Username, userName(uid)))
+	}
+}
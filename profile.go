@@ -0,0 +1,23 @@
+package sunshine
+
+import (
+	"slices"
+)
+
+// ProfileDebian selects Debian and Ubuntu permission conventions.
+const ProfileDebian = "debian"
+
+// ProfileRedHat selects RHEL, CentOS, and Fedora permission conventions.
+const ProfileRedHat = "rhel"
+
+// Profiles lists the supported profiles.
+var Profiles = []string{
+	ProfileDebian,
+	ProfileRedHat,
+	ProfileCISKubernetes,
+}
+
+// HasProfile reports whether the given profile is enabled.
+func (o Scanner) HasProfile(profile string) bool {
+	return slices.Contains(o.Profiles, profile)
+}
@@ -0,0 +1,72 @@
+package sunshine
+
+import (
+	"bytes"
+	"os"
+)
+
+// RegistryTokenFiles lists package registry credential files, relative to a home directory.
+var RegistryTokenFiles = []string{
+	".gem/credentials",
+	".cargo/credentials",
+	".cargo/credentials.toml",
+	".m2/settings-security.xml",
+	".config/gh/hosts.yml",
+}
+
+// RegistryConfigFiles maps package registry configuration files, relative to a home directory,
+// to markers indicating that the file also embeds publish tokens.
+var RegistryConfigFiles = map[string][]byte{
+	".npmrc":                []byte("_auth"),
+	".pypirc":               []byte("password"),
+	".cargo/config.toml":    []byte("token"),
+	".m2/settings.xml":      []byte("<password>"),
+	".config/gh/config.yml": []byte("oauth_token"),
+}
+
+// RegistryTokenTag labels warnings about token-bearing registry files.
+const RegistryTokenTag = "registry token file"
+
+// RegistryConfigTag labels warnings about registry configuration files without tokens.
+const RegistryConfigTag = "registry config file"
+
+// ScanRegistryTokens analyzes npm, PyPI, RubyGems, Cargo, Maven, and GitHub CLI credential files.
+//
+// Token-bearing files require owner-only access.
+// Token-free configuration siblings are reported separately,
+// and only require that others cannot write them.
+func (o Scanner) ScanRegistryTokens(pth string, info os.FileInfo) {
+	for _, tokenFile := range RegistryTokenFiles {
+		if hasPathSuffix(pth, tokenFile) {
+			o.ValidateFile(pth, info)
+			o.Tagged(RegistryTokenTag).ValidateChmodForbid(pth, info, 0077)
+			return
+		}
+	}
+
+	for configFile, marker := range RegistryConfigFiles {
+		if !hasPathSuffix(pth, configFile) {
+			continue
+		}
+
+		o.ValidateFile(pth, info)
+
+		if !info.Mode().IsRegular() {
+			return
+		}
+
+		contents, err := os.ReadFile(pth)
+
+		if err != nil {
+			return
+		}
+
+		if bytes.Contains(contents, marker) {
+			o.Tagged(RegistryTokenTag).ValidateChmodForbid(pth, info, 0077)
+		} else {
+			o.Tagged(RegistryConfigTag).ValidateChmodForbid(pth, info, 0022)
+		}
+
+		return
+	}
+}
@@ -0,0 +1,52 @@
+package sunshine
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// SecretFilePatterns match common application secret files, such as dotenv files,
+// Rails master keys, WordPress configurations, and Django local settings.
+var SecretFilePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(^|/)\.env(\.[^/]+)?$`),
+	regexp.MustCompile(`(^|/)secrets\.ya?ml$`),
+	regexp.MustCompile(`(^|/)config/master\.key$`),
+	regexp.MustCompile(`(^|/)wp-config\.php$`),
+	regexp.MustCompile(`(^|/)local_settings\.py$`),
+}
+
+// SecretTemplatePattern matches secret-free templates of secret files, such as .env.example.
+var SecretTemplatePattern = regexp.MustCompile(`\.(example|sample|template|dist)$`)
+
+// IsSecretFile reports whether a path is an application secret file.
+func IsSecretFile(pth string) bool {
+	p := filepath.ToSlash(pth)
+
+	if SecretTemplatePattern.MatchString(p) {
+		return false
+	}
+
+	for _, pattern := range SecretFilePatterns {
+		if pattern.MatchString(p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ScanSecretFiles analyzes dotenv and application secret files.
+//
+// StrictSecrets additionally requires that secret files not be group readable.
+func (o Scanner) ScanSecretFiles(pth string, info os.FileInfo) {
+	if info.IsDir() || !IsSecretFile(pth) {
+		return
+	}
+
+	if o.StrictSecrets {
+		o.Tagged("secret file").ValidateChmodForbid(pth, info, 0044)
+	} else {
+		o.Tagged("secret file").ValidateChmodForbid(pth, info, 0004)
+	}
+}
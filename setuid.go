@@ -0,0 +1,126 @@
+package sunshine
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SetuidAllowlists maps distribution profiles to glob patterns
+// matching their stock setuid and setgid files.
+var SetuidAllowlists = map[string][]string{
+	ProfileDebian: {
+		"/usr/bin/at",
+		"/usr/bin/bsd-write",
+		"/usr/bin/chage",
+		"/usr/bin/chfn",
+		"/usr/bin/chsh",
+		"/usr/bin/crontab",
+		"/usr/bin/dotlockfile",
+		"/usr/bin/expiry",
+		"/usr/bin/fusermount",
+		"/usr/bin/fusermount3",
+		"/usr/bin/gpasswd",
+		"/usr/bin/mount",
+		"/usr/bin/newgrp",
+		"/usr/bin/ntfs-3g",
+		"/usr/bin/passwd",
+		"/usr/bin/pkexec",
+		"/usr/bin/plocate",
+		"/usr/bin/ssh-agent",
+		"/usr/bin/su",
+		"/usr/bin/sudo",
+		"/usr/bin/umount",
+		"/usr/bin/wall",
+		"/usr/bin/write.ul",
+		"/usr/lib/*-linux-gnu/utempter/utempter",
+		"/usr/lib/chromium/chrome-sandbox",
+		"/usr/lib/dbus-1.0/dbus-daemon-launch-helper",
+		"/usr/lib/eject/dmcrypt-get-device",
+		"/usr/lib/openssh/ssh-keysign",
+		"/usr/lib/polkit-1/polkit-agent-helper-1",
+		"/usr/lib/snapd/snap-confine",
+		"/usr/lib/xorg/Xorg.wrap",
+		"/usr/sbin/exim4",
+		"/usr/sbin/pam_extrausers_chkpwd",
+		"/usr/sbin/pppd",
+		"/usr/sbin/unix_chkpwd",
+	},
+	ProfileRedHat: {
+		"/usr/bin/at",
+		"/usr/bin/chage",
+		"/usr/bin/crontab",
+		"/usr/bin/fusermount",
+		"/usr/bin/fusermount3",
+		"/usr/bin/gpasswd",
+		"/usr/bin/locate",
+		"/usr/bin/mount",
+		"/usr/bin/newgrp",
+		"/usr/bin/passwd",
+		"/usr/bin/pkexec",
+		"/usr/bin/ssh-agent",
+		"/usr/bin/su",
+		"/usr/bin/sudo",
+		"/usr/bin/umount",
+		"/usr/bin/write",
+		"/usr/lib/polkit-1/polkit-agent-helper-1",
+		"/usr/libexec/Xorg.wrap",
+		"/usr/libexec/cockpit-session",
+		"/usr/libexec/dbus-1/dbus-daemon-launch-helper",
+		"/usr/libexec/openssh/ssh-keysign",
+		"/usr/libexec/qemu-bridge-helper",
+		"/usr/libexec/sssd/*_child",
+		"/usr/libexec/utempter/utempter",
+		"/usr/sbin/grub2-set-bootflag",
+		"/usr/sbin/mount.nfs",
+		"/usr/sbin/netreport",
+		"/usr/sbin/pam_timestamp_check",
+		"/usr/sbin/postdrop",
+		"/usr/sbin/postqueue",
+		"/usr/sbin/unix_chkpwd",
+		"/usr/sbin/userhelper",
+		"/usr/sbin/usernetctl",
+	},
+}
+
+// Shebang marks interpreted scripts.
+var Shebang = []byte("#!")
+
+// SetuidAllowlist reports the stock setuid and setgid file patterns for the enabled distribution profile.
+func (o Scanner) SetuidAllowlist() []string {
+	if o.HasProfile(ProfileRedHat) {
+		return SetuidAllowlists[ProfileRedHat]
+	}
+
+	return SetuidAllowlists[ProfileDebian]
+}
+
+// ScanSetuid inventories setuid and setgid files as informational messages,
+// warning about files outside of the distribution allowlist,
+// setuid scripts, and setuid files writable by non-owners.
+func (o Scanner) ScanSetuid(pth string, info os.FileInfo, header []byte) {
+	observedMode := Chmod(info)
+
+	if !info.Mode().IsRegular() || observedMode&06000 == 0 {
+		return
+	}
+
+	o.Inform(pth, fmt.Sprintf("setuid/setgid file, got %04o", observedMode))
+
+	absPth, err := filepath.Abs(pth)
+
+	if err != nil {
+		absPth = pth
+	}
+
+	if !matchesAny(absPth, o.SetuidAllowlist()) {
+		o.Warn(pth, fmt.Sprintf("unexpected setuid/setgid file, got %04o", observedMode))
+	}
+
+	if bytes.HasPrefix(header, Shebang) {
+		o.Warn(pth, fmt.Sprintf("setuid/setgid script, got %04o", observedMode))
+	}
+
+	o.Tagged("setuid/setgid file").ValidateChmodForbid(pth, info, 0022)
+}
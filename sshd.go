@@ -0,0 +1,150 @@
+package sunshine
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SSHHostKeyPattern matches SSH host private key filenames.
+var SSHHostKeyPattern = regexp.MustCompile(`^ssh_host_.+_key$`)
+
+// SSHHostPublicKeyPattern matches SSH host public key filenames.
+var SSHHostPublicKeyPattern = regexp.MustCompile(`^ssh_host_.+_key\.pub$`)
+
+// ScanSSHHostKeys analyzes /etc/ssh/ssh_host_*_key(.pub)? files.
+//
+// ProfileRedHat systems may also keep host private keys at 0640 root:ssh_keys.
+func (o Scanner) ScanSSHHostKeys(pth string, info os.FileInfo) {
+	if filepath.Dir(pth) != "/etc/ssh" {
+		return
+	}
+
+	name := info.Name()
+
+	switch {
+	case SSHHostKeyPattern.MatchString(name):
+		o.ValidateFile(pth, info)
+		o.ValidateOwner(pth, info, "root")
+
+		if !o.HasProfile(ProfileRedHat) {
+			o.ValidateChmod(pth, info, 0600)
+			return
+		}
+
+		o.ValidateChmodAny(pth, info, 0600, 0640)
+
+		if Chmod(info) == 0640 {
+			o.ValidateGroup(pth, info, "ssh_keys")
+		}
+	case SSHHostPublicKeyPattern.MatchString(name):
+		o.ValidateFile(pth, info)
+		o.ValidateChmod(pth, info, 0644)
+		o.ValidateOwner(pth, info, "root")
+	}
+}
+
+// ScanSSHDConfig analyzes /etc/ssh/sshd_config, /etc/ssh/sshd_config.d/*, and /etc/ssh/moduli,
+// along with any TrustedUserCAKeys and AuthorizedKeysFile paths configured therein.
+//
+// Debian-like systems keep sshd configuration at 0644,
+// while ProfileRedHat systems keep it at 0600.
+func (o Scanner) ScanSSHDConfig(pth string, info os.FileInfo) {
+	switch {
+	case pth == "/etc/ssh/moduli":
+		o.ValidateFile(pth, info)
+		o.ValidateChmod(pth, info, 0644)
+		o.ValidateOwner(pth, info, "root")
+	case pth == "/etc/ssh/sshd_config", filepath.Dir(pth) == "/etc/ssh/sshd_config.d":
+		o.ValidateFile(pth, info)
+
+		if o.HasProfile(ProfileRedHat) {
+			o.ValidateChmod(pth, info, 0600)
+		} else {
+			o.ValidateChmod(pth, info, 0644)
+		}
+
+		o.ValidateOwner(pth, info, "root")
+
+		if info.Mode().IsRegular() {
+			o.ScanSSHDReferences(pth)
+		}
+	}
+}
+
+// expandSSHDTokens resolves sshd_config %-tokens for the current user.
+func (o Scanner) expandSSHDTokens(pth string) string {
+	replacements := []string{"%%", "%", "%h", o.Home}
+
+	if u, err := user.Current(); err == nil {
+		replacements = append(replacements, "%u", u.Username, "%U", u.Uid)
+	}
+
+	pth = strings.NewReplacer(replacements...).Replace(pth)
+
+	if !filepath.IsAbs(pth) {
+		pth = filepath.Join(o.Home, pth)
+	}
+
+	return pth
+}
+
+// ScanSSHDReferences analyzes the TrustedUserCAKeys and AuthorizedKeysFile paths
+// of an sshd configuration file.
+//
+// AuthorizedKeysFile paths are resolved for the current user.
+// Unreadable configuration files are skipped.
+func (o Scanner) ScanSSHDReferences(configPth string) {
+	contents, err := os.ReadFile(configPth)
+
+	if err != nil {
+		return
+	}
+
+	for _, line := range strings.Split(string(contents), "\n") {
+		fields := strings.Fields(line)
+
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		keyword := strings.ToLower(fields[0])
+
+		for _, value := range fields[1:] {
+			value = strings.Trim(value, `"`)
+
+			if value == "none" {
+				continue
+			}
+
+			switch keyword {
+			case "trustedusercakeys":
+				o.validateSSHDReference(configPth, fields[0], o.expandSSHDTokens(value), true)
+			case "authorizedkeysfile":
+				o.validateSSHDReference(configPth, fields[0], o.expandSSHDTokens(value), false)
+			}
+		}
+	}
+}
+
+// validateSSHDReference enforces policies on a path referenced from an sshd configuration file.
+//
+// Missing paths are ignored.
+func (o Scanner) validateSSHDReference(configPth string, keyword string, pth string, rootOwned bool) {
+	info, err := os.Stat(pth)
+
+	if err != nil {
+		return
+	}
+
+	t := o.Tagged(fmt.Sprintf("%s in %s", keyword, configPth))
+	t.ValidateFile(pth, info)
+	t.ValidateChmodForbid(pth, info, 0022)
+
+	if rootOwned {
+		t.ValidateOwner(pth, info, "root")
+	}
+}
@@ -0,0 +1,25 @@
+package sunshine
+
+import (
+	"os"
+	"slices"
+)
+
+// SharedTempDirectories lists system temporary directories, which require chmod 1777.
+var SharedTempDirectories = []string{
+	"/tmp",
+	"/var/tmp",
+	"/dev/shm",
+}
+
+// ScanSticky analyzes world-writable directories for missing sticky bits,
+// which would let any user delete or replace other users' files.
+func (o Scanner) ScanSticky(pth string, info os.FileInfo) {
+	if slices.Contains(SharedTempDirectories, pth) {
+		o.ValidateDirectory(pth, info)
+		o.ValidateChmod(pth, info, 01777)
+		return
+	}
+
+	o.ValidateSticky(pth, info)
+}
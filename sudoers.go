@@ -0,0 +1,36 @@
+package sunshine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ScanSudoers analyzes /etc/sudoers, /etc/sudoers.d, and its included files.
+//
+// sudo refuses to run with mis-permissioned sudoers files,
+// and silently skips included files whose names contain a dot or end in a tilde.
+func (o Scanner) ScanSudoers(pth string, info os.FileInfo) {
+	switch {
+	case pth == "/etc/sudoers":
+		o.ValidateFile(pth, info)
+		o.ValidateChmod(pth, info, 0440)
+		o.ValidateOwner(pth, info, "root")
+		o.ValidateGroup(pth, info, "root")
+	case pth == "/etc/sudoers.d":
+		o.ValidateDirectory(pth, info)
+		o.ValidateChmodForbid(pth, info, 0027)
+		o.ValidateOwner(pth, info, "root")
+	case filepath.Dir(pth) == "/etc/sudoers.d":
+		o.ValidateFile(pth, info)
+		o.ValidateChmod(pth, info, 0440)
+		o.ValidateOwner(pth, info, "root")
+		o.ValidateGroup(pth, info, "root")
+
+		name := info.Name()
+
+		if strings.Contains(name, ".") || strings.HasSuffix(name, "~") {
+			o.Warn(pth, "sudo ignores included files with names containing . or ending in ~")
+		}
+	}
+}
@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/user"
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 )
 
@@ -29,6 +33,13 @@ type Scanner struct {
 	// WarnCh signals permission discrepancies.
 	WarnCh chan string
 
+	// InfoCh signals informational anomalies.
+	//
+	// InfoCh is optional. NewScanner leaves it nil, which discards informational messages,
+	// so that callers selecting only on the other channels do not block.
+	// Assign a channel before scanning in order to receive them.
+	InfoCh chan string
+
 	// ErrCh signals errors experienced during scan attempts.
 	ErrCh chan error
 
@@ -37,6 +48,24 @@ type Scanner struct {
 
 	// Home denotes the current user's home directory.
 	Home string
+
+	// KubeConfigs denotes additional Kubernetes client configuration files.
+	KubeConfigs []string
+
+	// StrictSecrets requires application secret files to not be group readable.
+	StrictSecrets bool
+
+	// DocumentRoots denotes web server document root directories.
+	DocumentRoots []string
+
+	// Profiles select distribution specific and optional rule variants.
+	Profiles []string
+
+	// dataDirectories maps database data directories to their owner UIDs.
+	dataDirectories *sync.Map
+
+	// Tag annotates warnings with the rule responsible for them.
+	Tag string
 }
 
 // NewScanner constructs a scanner.
@@ -52,16 +81,47 @@ func NewScanner(debug bool) (*Scanner, error) {
 	errCh := make(chan error)
 	doneCh := make(chan struct{})
 	scanner := Scanner{
-		Debug:   debug,
-		DebugCh: debugCh,
-		WarnCh:  warnCh,
-		ErrCh:   errCh,
-		DoneCh:  doneCh,
-		Home:    home,
+		Debug:           debug,
+		DebugCh:         debugCh,
+		WarnCh:          warnCh,
+		ErrCh:           errCh,
+		DoneCh:          doneCh,
+		Home:            home,
+		DocumentRoots:   slices.Clone(DefaultDocumentRoots),
+		dataDirectories: new(sync.Map),
 	}
 	return &scanner, nil
 }
 
+// Tagged derives a scanner annotating its warnings with the given tag.
+func (o Scanner) Tagged(tag string) *Scanner {
+	o.Tag = tag
+	return &o
+}
+
+// Warn signals a permission discrepancy for the given path.
+func (o Scanner) Warn(pth string, msg string) {
+	if o.Tag != "" {
+		msg = fmt.Sprintf("%s: %s", o.Tag, msg)
+	}
+
+	o.WarnCh <- fmt.Sprintf("%s: %s", pth, msg)
+}
+
+// Inform signals an informational anomaly for the given path,
+// when InfoCh is configured.
+func (o Scanner) Inform(pth string, msg string) {
+	if o.InfoCh == nil {
+		return
+	}
+
+	if o.Tag != "" {
+		msg = fmt.Sprintf("%s: %s", o.Tag, msg)
+	}
+
+	o.InfoCh <- fmt.Sprintf("%s: %s", pth, msg)
+}
+
 // CheckFileExists checks paths for existence.
 func (o Scanner) CheckFileExists(pth string, _ os.FileInfo) error {
 	_, err := os.Stat(pth)
@@ -76,40 +136,319 @@ func (o Scanner) CheckFileExists(pth string, _ os.FileInfo) error {
 // ValidateDirectory enforces the given directory policy.
 func (o *Scanner) ValidateDirectory(pth string, info os.FileInfo) {
 	if !info.IsDir() {
-		o.WarnCh <- fmt.Sprintf("%s: expected directory, got file", pth)
+		o.Warn(pth, "expected directory, got file")
 	}
 }
 
 // ValidateFile enforces the given file policy.
 func (o *Scanner) ValidateFile(pth string, info os.FileInfo) {
 	if info.IsDir() {
-		o.WarnCh <- fmt.Sprintf("%s: expected file, got directory", pth)
+		o.Warn(pth, "expected file, got directory")
+	}
+}
+
+// ValidateRegularFile enforces the given regular file policy.
+func (o *Scanner) ValidateRegularFile(pth string, info os.FileInfo) {
+	if !info.Mode().IsRegular() {
+		o.Warn(pth, fmt.Sprintf("expected regular file, got %s", info.Mode().Type()))
 	}
 }
 
+// ValidateSocket enforces the given socket policy.
+func (o *Scanner) ValidateSocket(pth string, info os.FileInfo) {
+	if info.Mode()&os.ModeSocket == 0 {
+		o.Warn(pth, fmt.Sprintf("expected socket, got %s", info.Mode().Type()))
+	}
+}
+
+// Chmod reports the UNIX permission bits of a path,
+// including the setuid (04000), setgid (02000), and sticky (01000) bits.
+func Chmod(info os.FileInfo) os.FileMode {
+	mode := info.Mode()
+	chmod := mode.Perm()
+
+	if mode&os.ModeSetuid != 0 {
+		chmod |= 04000
+	}
+
+	if mode&os.ModeSetgid != 0 {
+		chmod |= 02000
+	}
+
+	if mode&os.ModeSticky != 0 {
+		chmod |= 01000
+	}
+
+	return chmod
+}
+
 // ValidateChmod enforces the given chmod policy.
 func (o *Scanner) ValidateChmod(pth string, info os.FileInfo, expectedMode os.FileMode) {
-	observedMode := info.Mode() % 01000
+	observedMode := Chmod(info)
 
 	if expectedMode != observedMode {
-		o.WarnCh <- fmt.Sprintf("%s: expected chmod %04o, got %04o", pth, expectedMode, observedMode)
+		o.Warn(pth, fmt.Sprintf("expected chmod %04o, got %04o", expectedMode, observedMode))
+	}
+}
+
+// ValidateChmodAny enforces any one of the given chmod policies.
+func (o *Scanner) ValidateChmodAny(pth string, info os.FileInfo, expectedModes ...os.FileMode) {
+	observedMode := Chmod(info)
+
+	if slices.Contains(expectedModes, observedMode) {
+		return
 	}
+
+	var expected []string
+
+	for _, expectedMode := range expectedModes {
+		expected = append(expected, fmt.Sprintf("%04o", expectedMode))
+	}
+
+	o.Warn(pth, fmt.Sprintf("expected chmod %s, got %04o", strings.Join(expected, " or "), observedMode))
 }
 
 // ValidateChmodMask enforces the given chmod mask policy.
 func (o *Scanner) ValidateChmodMask(pth string, info os.FileInfo, expectedMask os.FileMode) {
-	observedMode := info.Mode() % 01000
+	observedMode := Chmod(info)
 
 	if expectedMask&observedMode == 0 {
-		o.WarnCh <- fmt.Sprintf("%s: expected chmod mask to union with %04o, got %04o", pth, expectedMask, observedMode)
+		o.Warn(pth, fmt.Sprintf("expected chmod mask to union with %04o, got %04o", expectedMask, observedMode))
+	}
+}
+
+// ValidateChmodMax enforces the given chmod policy or stricter.
+func (o *Scanner) ValidateChmodMax(pth string, info os.FileInfo, maxMode os.FileMode) {
+	observedMode := Chmod(info)
+
+	if observedMode&^maxMode != 0 {
+		o.Warn(pth, fmt.Sprintf("expected chmod %04o or stricter, got %04o", maxMode, observedMode))
+	}
+}
+
+// ValidateSticky enforces the sticky bit on world-writable directories.
+func (o *Scanner) ValidateSticky(pth string, info os.FileInfo) {
+	observedMode := Chmod(info)
+
+	if info.IsDir() && observedMode&0002 != 0 && observedMode&01000 == 0 {
+		o.Warn(pth, fmt.Sprintf("expected sticky bit on world-writable directory, got %04o", observedMode))
+	}
+}
+
+// ValidateChmodForbid enforces the given forbidden chmod mask policy.
+func (o *Scanner) ValidateChmodForbid(pth string, info os.FileInfo, forbiddenMask os.FileMode) {
+	observedMode := Chmod(info)
+
+	if forbiddenMask&observedMode != 0 {
+		o.Warn(pth, fmt.Sprintf("expected chmod mask to exclude %04o, got %04o", forbiddenMask, observedMode))
+	}
+}
+
+// PseudoFileSystems lists kernel interface mounts, whose files may block or consume data when read.
+var PseudoFileSystems = []string{
+	"/proc",
+	"/sys",
+}
+
+// Sniffable reports whether a file is safe to read for content detection:
+// a nonempty regular file outside of PseudoFileSystems.
+func Sniffable(pth string, info os.FileInfo) bool {
+	if !info.Mode().IsRegular() || info.Size() == 0 {
+		return false
+	}
+
+	return !withinAny(pth, PseudoFileSystems)
+}
+
+// SniffSize denotes how many leading bytes of Sniffable files are read for content detection.
+const SniffSize = 512
+
+// sniff reads up to the first n bytes of a file.
+func sniff(pth string, n int) ([]byte, error) {
+	f, err := os.Open(pth)
+
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, n)
+	count, err := io.ReadFull(f, buf)
+
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, errors.Join(err, f.Close())
+	}
+
+	if err2 := f.Close(); err2 != nil {
+		return nil, err2
+	}
+
+	return buf[:count], nil
+}
+
+// hasPathSuffix reports whether a path ends with the given slash separated path elements.
+func hasPathSuffix(pth string, suffix string) bool {
+	p := filepath.ToSlash(pth)
+	return p == suffix || strings.HasSuffix(p, "/"+suffix)
+}
+
+// within reports whether a path lies inside the given root.
+func within(pth string, root string) bool {
+	absPth, err := filepath.Abs(pth)
+
+	if err != nil {
+		return false
+	}
+
+	absRoot, err := filepath.Abs(root)
+
+	if err != nil {
+		return false
+	}
+
+	rel, err := filepath.Rel(absRoot, absPth)
+
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// samePath reports whether two paths refer to the same location.
+func samePath(pth string, other string) bool {
+	absPth, err := filepath.Abs(pth)
+
+	if err != nil {
+		return false
+	}
+
+	absOther, err := filepath.Abs(other)
+
+	if err != nil {
+		return false
+	}
+
+	return absPth == absOther
+}
+
+// withinAny reports whether a path lies inside any of the given roots.
+func withinAny(pth string, roots []string) bool {
+	for _, root := range roots {
+		if within(pth, root) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesAny reports whether a path matches any of the given glob patterns.
+func matchesAny(pth string, patterns []string) bool {
+	pth = filepath.ToSlash(pth)
+
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, pth); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// userName resolves a numeric user ID, falling back to the ID itself.
+func userName(uid uint32) string {
+	id := strconv.FormatUint(uint64(uid), 10)
+	u, err := user.LookupId(id)
+
+	if err != nil {
+		return id
+	}
+
+	return u.Username
+}
+
+// groupName resolves a numeric group ID, falling back to the ID itself.
+func groupName(gid uint32) string {
+	id := strconv.FormatUint(uint64(gid), 10)
+	g, err := user.LookupGroupId(id)
+
+	if err != nil {
+		return id
+	}
+
+	return g.Name
+}
+
+// ValidateOwner enforces the given owner policy.
+//
+// Platforms without UNIX ownership skip this check.
+func (o *Scanner) ValidateOwner(pth string, info os.FileInfo, expectedOwner string) {
+	uid, _, ok := fileOwnership(info)
+
+	if !ok {
+		return
+	}
+
+	observedOwner := userName(uid)
+
+	if expectedOwner != observedOwner {
+		o.Warn(pth, fmt.Sprintf("expected owner %s, got %s", expectedOwner, observedOwner))
+	}
+}
+
+// ValidateGroup enforces the given group policy.
+//
+// Platforms without UNIX ownership skip this check.
+func (o *Scanner) ValidateGroup(pth string, info os.FileInfo, expectedGroup string) {
+	_, gid, ok := fileOwnership(info)
+
+	if !ok {
+		return
+	}
+
+	observedGroup := groupName(gid)
+
+	if expectedGroup != observedGroup {
+		o.Warn(pth, fmt.Sprintf("expected group %s, got %s", expectedGroup, observedGroup))
+	}
+}
+
+// ValidateOwnerOf enforces an owner policy matching the owner of a reference path,
+// such as the containing home directory.
+//
+// Platforms without UNIX ownership skip this check.
+func (o *Scanner) ValidateOwnerOf(pth string, info os.FileInfo, referencePth string) {
+	uid, _, ok := fileOwnership(info)
+
+	if !ok {
+		return
+	}
+
+	referenceInfo, err := os.Stat(referencePth)
+
+	if err != nil {
+		return
+	}
+
+	referenceUID, _, ok := fileOwnership(referenceInfo)
+
+	if !ok {
+		return
+	}
+
+	if uid != referenceUID {
+		o.Warn(pth, fmt.Sprintf("expected owner %s (same as %s), got %s", userName(referenceUID), referencePth, userName(uid)))
 	}
 }
 
-// ScanInvisible analyzes paths for missing u+x (directories) or u+r (files) bits.
+// ScanInvisible analyzes paths for missing u+x (directories), u+w (sockets), or u+r (files) bits.
 func (o Scanner) ScanInvisible(pth string, info os.FileInfo) {
-	if info.IsDir() {
+	switch {
+	case info.IsDir():
 		o.ValidateChmodMask(pth, info, 0500)
-	} else {
+	case info.Mode()&os.ModeSocket != 0:
+		o.ValidateChmodMask(pth, info, 0200)
+	default:
 		o.ValidateChmodMask(pth, info, 0400)
 	}
 }
@@ -119,6 +458,7 @@ func (o Scanner) ScanEtcSSH(pth string, info os.FileInfo) {
 	if pth == "/etc" || pth == "/etc/ssh" {
 		o.ValidateDirectory(pth, info)
 		o.ValidateChmod(pth, info, 0755)
+		o.ValidateOwner(pth, info, "root")
 	}
 }
 
@@ -127,6 +467,7 @@ func (o Scanner) ScanUserSSH(pth string, info os.FileInfo) {
 	if info.Name() == ".ssh" {
 		o.ValidateDirectory(pth, info)
 		o.ValidateChmod(pth, info, 0700)
+		o.ValidateOwnerOf(pth, info, filepath.Dir(pth))
 	}
 }
 
@@ -138,6 +479,7 @@ func (o Scanner) ScanSSHConfig(pth string, info os.FileInfo) {
 		if parent == ".ssh" {
 			o.ValidateFile(pth, info)
 			o.ValidateChmod(pth, info, 0400)
+			o.ValidateOwnerOf(pth, info, filepath.Dir(filepath.Dir(pth)))
 		}
 	}
 }
@@ -157,6 +499,8 @@ func (o Scanner) ScanSSHKeys(pth string, info os.FileInfo) {
 			} else {
 				o.ValidateChmod(pth, info, 0600)
 			}
+
+			o.ValidateOwnerOf(pth, info, filepath.Dir(filepath.Dir(pth)))
 		}
 	}
 }
@@ -166,6 +510,7 @@ func (o Scanner) ScanSSHAuthorizedKeys(pth string, info os.FileInfo) {
 	if info.Name() == "authorized_keys" {
 		o.ValidateFile(pth, info)
 		o.ValidateChmod(pth, info, 0600)
+		o.ValidateOwnerOf(pth, info, filepath.Dir(filepath.Dir(pth)))
 	}
 }
 
@@ -200,6 +545,8 @@ func (o *Scanner) Walk(pth string, info os.FileInfo, _ error) error {
 		return err
 	}
 
+	o.ScanHistorySymlinks(pth, info)
+
 	if info.Mode()&os.ModeSymlink != 0 {
 		p, err2 := os.Readlink(pth)
 
@@ -218,16 +565,65 @@ func (o *Scanner) Walk(pth string, info os.FileInfo, _ error) error {
 	o.ScanSSHKeys(pth, info)
 	o.ScanSSHAuthorizedKeys(pth, info)
 	o.ScanSSHKnownHosts(pth, info)
+	o.ScanGnuPGHome(pth, info)
+	o.ScanGnuPGFiles(pth, info)
+	o.ScanGnuPGPrivateKeys(pth, info)
+	o.ScanCloudCredentials(pth, info)
+	o.ScanKubeHome(pth, info)
+	o.ScanKubeConfig(pth, info)
+	o.ScanKubeCache(pth, info)
+	o.ScanDockerClientConfig(pth, info)
+	o.ScanDockerDaemon(pth, info)
+	o.ScanDockerCerts(pth, info)
+	o.ScanDockerSocket(pth, info)
+	o.ScanPasswordFiles(pth, info)
+	o.ScanRegistryTokens(pth, info)
+	o.ScanGitCredentials(pth, info)
+	o.ScanGitHooks(pth, info)
+	o.ScanGitConfig(pth, info)
+
+	var header []byte
+
+	if Sniffable(pth, info) {
+		if h, err2 := sniff(pth, SniffSize); err2 == nil {
+			header = h
+		}
+	}
+
+	o.ScanPrivateKeys(pth, info, header)
+	o.ScanSecretFiles(pth, info)
+	o.ScanTerraform(pth, info)
+	o.ScanKeystores(pth, info, header)
+	o.ScanHistory(pth, info)
+	o.ScanAccountDatabases(pth, info)
+	o.ScanSudoers(pth, info)
+	o.ScanSSHHostKeys(pth, info)
+	o.ScanSSHDConfig(pth, info)
+	o.ScanCron(pth, info)
+	o.ScanCrontabs(pth, info)
+	o.ScanSystemdUnits(pth, info)
+	o.ScanSticky(pth, info)
+	o.ScanSetuid(pth, info, header)
+	o.ScanCapabilities(pth, info)
+	o.ScanCISKubernetes(pth, info)
+	o.ScanDataDirectories(pth, info)
+	o.ScanDataOwnership(pth, info)
+	o.ScanRedis(pth, info)
+	o.ScanHtaccess(pth, info)
+	o.ScanWebServerConfig(pth, info)
+	o.ScanDocumentRoots(pth, info)
 	return nil
 }
 
 // Illuminate pours through the given file paths recursively
 // for known permission discrepancies.
-func Illuminate(roots []string, debug bool) (*Scanner, error) {
-	scanner, err := NewScanner(debug)
-
-	if err != nil {
-		return nil, err
+//
+// Any KubeConfigs outside of the given roots are scanned as well.
+func (o *Scanner) Illuminate(roots []string) {
+	for _, kubeConfig := range o.KubeConfigs {
+		if !withinAny(kubeConfig, roots) {
+			roots = append(roots, kubeConfig)
+		}
 	}
 
 	var wg sync.WaitGroup
@@ -237,16 +633,27 @@ func Illuminate(roots []string, debug bool) (*Scanner, error) {
 		go func(r string, w *sync.WaitGroup) {
 			defer w.Done()
 
-			if err2 := filepath.Walk(r, scanner.Walk); err2 != nil && err2 != io.EOF {
-				scanner.ErrCh <- err2
+			if err := filepath.Walk(r, o.Walk); err != nil && err != io.EOF {
+				o.ErrCh <- err
 			}
 		}(root, &wg)
 	}
 
 	go func() {
 		wg.Wait()
-		scanner.DoneCh <- struct{}{}
+		o.DoneCh <- struct{}{}
 	}()
+}
+
+// Illuminate pours through the given file paths recursively
+// for known permission discrepancies.
+func Illuminate(roots []string, debug bool) (*Scanner, error) {
+	scanner, err := NewScanner(debug)
+
+	if err != nil {
+		return nil, err
+	}
 
+	scanner.Illuminate(roots)
 	return scanner, nil
 }
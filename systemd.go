@@ -0,0 +1,142 @@
+package sunshine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// SystemdUnitDirectories lists system unit directories.
+var SystemdUnitDirectories = []string{
+	"/etc/systemd/system",
+	"/lib/systemd/system",
+	"/usr/lib/systemd/system",
+}
+
+// SystemdUserUnitDirectory denotes the per-user unit directory, relative to a home directory.
+const SystemdUserUnitDirectory = ".config/systemd/user"
+
+// SystemdExecKeys lists unit settings which launch commands.
+var SystemdExecKeys = []string{
+	"ExecStart",
+	"ExecStartPre",
+	"ExecStartPost",
+	"ExecReload",
+	"ExecStop",
+	"ExecStopPost",
+}
+
+// ScanSystemdUnits analyzes system and per-user systemd unit files and their *.d drop-ins.
+//
+// Symlinks, such as enablement links in *.wants directories, are skipped.
+func (o Scanner) ScanSystemdUnits(pth string, info os.FileInfo) {
+	if info.IsDir() || info.Mode()&os.ModeSymlink != 0 {
+		return
+	}
+
+	for _, dir := range SystemdUnitDirectories {
+		if strings.HasPrefix(pth, dir+"/") {
+			o.ValidateFile(pth, info)
+			o.ValidateChmodForbid(pth, info, 0022)
+			o.ValidateOwner(pth, info, "root")
+
+			if info.Mode().IsRegular() {
+				o.ScanSystemdExecs(pth, "")
+			}
+
+			return
+		}
+	}
+
+	p := filepath.ToSlash(pth)
+	marker := SystemdUserUnitDirectory + "/"
+	i := strings.Index(p, "/"+marker)
+
+	if i == -1 && !strings.HasPrefix(p, marker) {
+		return
+	}
+
+	home := "."
+
+	if i != -1 {
+		home = filepath.Clean(filepath.FromSlash(p[:i+1]))
+	}
+
+	o.ValidateFile(pth, info)
+	o.ValidateChmodForbid(pth, info, 0022)
+	o.ValidateOwnerOf(pth, info, home)
+
+	if info.Mode().IsRegular() {
+		o.ScanSystemdExecs(pth, home)
+	}
+}
+
+// ScanSystemdExecs analyzes the binaries launched by a unit file,
+// which must not be writable by anyone other than their owner.
+// Binaries of system units must be owned by root.
+// Binaries of per-user units, denoted by a nonempty home, may also be owned by the home owner.
+//
+// Unreadable unit files are skipped.
+func (o Scanner) ScanSystemdExecs(unitPth string, home string) {
+	contents, err := os.ReadFile(unitPth)
+
+	if err != nil {
+		return
+	}
+
+	t := o.Tagged(fmt.Sprintf("command of %s", unitPth))
+
+	for _, line := range strings.Split(string(contents), "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+
+		if !ok || !slices.Contains(SystemdExecKeys, strings.TrimSpace(key)) {
+			continue
+		}
+
+		fields := strings.Fields(strings.TrimLeft(strings.TrimSpace(value), "@-:+!"))
+
+		if len(fields) == 0 || !filepath.IsAbs(fields[0]) {
+			continue
+		}
+
+		pth := fields[0]
+		info, err := os.Stat(pth)
+
+		if err != nil {
+			continue
+		}
+
+		t.ValidateChmodForbid(pth, info, 0022)
+
+		if home == "" {
+			t.ValidateOwner(pth, info, "root")
+		} else {
+			t.validateRootOrOwnerOf(pth, info, home)
+		}
+	}
+}
+
+// validateRootOrOwnerOf enforces an owner policy of root, or the owner of a reference path.
+//
+// Platforms without UNIX ownership skip this check.
+func (o *Scanner) validateRootOrOwnerOf(pth string, info os.FileInfo, referencePth string) {
+	uid, _, ok := fileOwnership(info)
+
+	if !ok || uid == 0 {
+		return
+	}
+
+	referenceInfo, err := os.Stat(referencePth)
+
+	if err != nil {
+		return
+	}
+
+	referenceUID, _, ok := fileOwnership(referenceInfo)
+
+	if ok && uid != referenceUID {
+		o.Warn(pth, fmt.Sprintf("expected owner root or %s (same as %s), got %s", userName(referenceUID), referencePth, userName(uid)))
+	}
+}
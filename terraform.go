@@ -0,0 +1,27 @@
+package sunshine
+
+import (
+	"os"
+	"regexp"
+)
+
+// TerraformStatePattern matches Terraform state files and their backups.
+var TerraformStatePattern = regexp.MustCompile(`^(terraform|errored)\.tfstate(\.backup)?$`)
+
+// TerraformVariablesPattern matches Terraform variable definition files, including *.auto.tfvars.
+var TerraformVariablesPattern = regexp.MustCompile(`\.tfvars(\.json)?$`)
+
+// ScanTerraform analyzes Terraform state files, variable files, and .terraform directories,
+// which may hold provider credentials and generated secrets in cleartext.
+func (o Scanner) ScanTerraform(pth string, info os.FileInfo) {
+	name := info.Name()
+
+	switch {
+	case name == ".terraform":
+		o.ValidateDirectory(pth, info)
+		o.ValidateChmodForbid(pth, info, 0077)
+	case TerraformStatePattern.MatchString(name), TerraformVariablesPattern.MatchString(name):
+		o.ValidateFile(pth, info)
+		o.ValidateChmodForbid(pth, info, 0077)
+	}
+}
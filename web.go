@@ -0,0 +1,132 @@
+package sunshine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DefaultDocumentRoots lists common web server document roots.
+var DefaultDocumentRoots = []string{
+	"/var/www",
+	"/srv/www",
+	"/usr/share/nginx/html",
+}
+
+// WebServerConfigDirectories lists nginx and Apache configuration directories.
+var WebServerConfigDirectories = []string{
+	"/etc/nginx",
+	"/etc/apache2",
+	"/etc/httpd",
+}
+
+// WebServerConfigDirectoryNames lists directory names holding extensionless web server configuration files.
+var WebServerConfigDirectoryNames = []string{
+	"conf.d",
+	"sites-available",
+	"sites-enabled",
+}
+
+// WebServerKeyDirectives lists configuration directives referencing TLS private keys.
+var WebServerKeyDirectives = []string{
+	"ssl_certificate_key",
+	"sslcertificatekeyfile",
+}
+
+// UploadDirectoryNames lists document root directory names which receive user uploads.
+var UploadDirectoryNames = []string{
+	"upload",
+	"uploads",
+}
+
+// ScanHtaccess analyzes .htaccess and .htpasswd files.
+func (o Scanner) ScanHtaccess(pth string, info os.FileInfo) {
+	name := info.Name()
+
+	if name == ".htaccess" || name == ".htpasswd" {
+		o.ValidateFile(pth, info)
+		o.ValidateChmodForbid(pth, info, 0002)
+	}
+}
+
+// ScanWebServerConfig analyzes nginx and Apache configuration files,
+// along with any TLS private keys referenced therein.
+func (o Scanner) ScanWebServerConfig(pth string, info os.FileInfo) {
+	for _, dir := range WebServerConfigDirectories {
+		if pth != dir && !strings.HasPrefix(pth, dir+"/") {
+			continue
+		}
+
+		o.ValidateOwner(pth, info, "root")
+
+		if info.Mode().IsRegular() && isWebServerConfig(pth) {
+			o.ScanWebServerKeys(pth)
+		}
+
+		return
+	}
+}
+
+// isWebServerConfig reports whether a path names a web server configuration file,
+// as opposed to TLS material or other assets kept alongside.
+func isWebServerConfig(pth string) bool {
+	return filepath.Ext(pth) == ".conf" || slices.Contains(WebServerConfigDirectoryNames, filepath.Base(filepath.Dir(pth)))
+}
+
+// ScanWebServerKeys analyzes the TLS private keys referenced by a web server configuration file,
+// which must be root owned and not world readable.
+func (o Scanner) ScanWebServerKeys(configPth string) {
+	contents, err := os.ReadFile(configPth)
+
+	if err != nil {
+		return
+	}
+
+	for _, line := range strings.Split(string(contents), "\n") {
+		fields := strings.Fields(line)
+
+		if len(fields) < 2 || !slices.Contains(WebServerKeyDirectives, strings.ToLower(fields[0])) {
+			continue
+		}
+
+		pth := strings.Trim(strings.TrimSuffix(fields[1], ";"), `"'`)
+
+		if !filepath.IsAbs(pth) {
+			continue
+		}
+
+		info, err2 := os.Stat(pth)
+
+		if err2 != nil {
+			continue
+		}
+
+		t := o.Tagged(fmt.Sprintf("%s in %s", fields[0], configPth))
+		t.ValidateOwner(pth, info, "root")
+		t.ValidateChmodForbid(pth, info, 0004)
+	}
+}
+
+// ScanDocumentRoots analyzes DocumentRoots trees for group or world writable paths,
+// and for executable files in upload directories.
+func (o Scanner) ScanDocumentRoots(pth string, info os.FileInfo) {
+	if info.Mode()&os.ModeSymlink != 0 || !withinAny(pth, o.DocumentRoots) {
+		return
+	}
+
+	t := o.Tagged("document root")
+	t.ValidateChmodForbid(pth, info, 0022)
+
+	if !info.Mode().IsRegular() || Chmod(info)&0111 == 0 {
+		return
+	}
+
+	for _, element := range strings.Split(filepath.ToSlash(filepath.Dir(pth)), "/") {
+		if slices.Contains(UploadDirectoryNames, strings.ToLower(element)) {
+			t.Warn(pth, fmt.Sprintf("expected non-executable upload, got %04o", Chmod(info)))
+			return
+		}
+	}
+}